@@ -1,8 +1,11 @@
 package cfg
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,17 +17,26 @@ import (
 
 type eosPatterns struct {
 	globalCommentLinePattern *regexp.Regexp
-	bannerPattern            *regexp.Regexp
 	endPattern               *regexp.Regexp
 }
 
+// ErrInvalidConfigSessionName is returned when a configuration session name is too short to
+// derive a session prompt pattern from - this can happen when resumeConfigSession loads a
+// hand-edited or truncated entry out of a SessionStore.
+var ErrInvalidConfigSessionName = errors.New("invalid configuration session name")
+
+// ErrConfigCommandFailed is returned when the device accepts a configuration command without
+// a transport error but reports a failure in its own output - for example a "commit", "commit
+// timer", "abort", or "show session-config diffs" rejected by EOS for conflicting session
+// state or unsupported syntax.
+var ErrConfigCommandFailed = errors.New("device reported a configuration command failure")
+
 var eosPatternsInstance *eosPatterns
 
 func getEosPatterns() *eosPatterns {
 	if eosPatternsInstance == nil {
 		eosPatternsInstance = &eosPatterns{
 			globalCommentLinePattern: regexp.MustCompile(`(?im)^! .*$`),
-			bannerPattern:            regexp.MustCompile(`(?ims)^banner.*EOF$`),
 			endPattern:               regexp.MustCompile(`end$`),
 		}
 	}
@@ -37,6 +49,7 @@ type EOSCfg struct {
 	VersionPattern    *regexp.Regexp
 	configCommandMap  map[string]string
 	configSessionName string
+	sessionStore      SessionStore
 }
 
 // NewEOSCfg return a cfg instance setup for an Arista EOS device.
@@ -68,8 +81,70 @@ func NewEOSCfg(
 	return c, nil
 }
 
+// ClearConfigSession clears the in-progress configuration session, deleting it from the
+// configured SessionStore, if any, so it is not mistakenly resumed on a future restart. The
+// in-memory session name is reset unconditionally, even if the SessionStore delete fails - by
+// the time this is called the device-side session has already been committed, confirmed, or
+// aborted, so a caller must not be told the operation failed because of an unrelated local
+// persistence error.
 func (p *EOSCfg) ClearConfigSession() {
+	sessionName := p.configSessionName
+
 	p.configSessionName = ""
+
+	if p.sessionStore == nil || sessionName == "" {
+		return
+	}
+
+	if err := p.sessionStore.Delete(p.conn.Host); err != nil {
+		logging.LogError(
+			FormatLogMessage(
+				p.conn,
+				"error",
+				fmt.Sprintf("failed deleting persisted session %s: %v", sessionName, err),
+			),
+		)
+	}
+}
+
+// resumeConfigSession looks up a persisted in-progress session for this device and, if one
+// exists, re-registers its prompt pattern rather than letting the next LoadConfig allocate
+// (and orphan the device-side half of) a brand new session.
+func (p *EOSCfg) resumeConfigSession() error {
+	if p.sessionStore == nil {
+		return nil
+	}
+
+	session, err := p.sessionStore.Load(p.conn.Host)
+	if err != nil {
+		return err
+	}
+
+	if session == "" {
+		return nil
+	}
+
+	if err := p.RegisterConfigSession(session); err != nil && !errors.Is(err, ErrConfigSessionAlreadyExists) {
+		return err
+	}
+
+	p.configSessionName = session
+
+	return nil
+}
+
+// ListPendingSessions returns the host -> session name pairs currently persisted in the
+// configured SessionStore. Only SessionStore implementations that also support listing
+// (such as FileSessionStore) can report pending sessions; others return an empty map.
+func (p *EOSCfg) ListPendingSessions() (map[string]string, error) {
+	lister, ok := p.sessionStore.(interface {
+		ListPendingSessions() (map[string]string, error)
+	})
+	if !ok {
+		return map[string]string{}, nil
+	}
+
+	return lister.ListPendingSessions()
 }
 
 // GetVersion get the version from the device.
@@ -119,8 +194,10 @@ func (p *EOSCfg) prepareConfigPayloads(config string) (stdConfig, eagerConfig st
 	config = patterns.endPattern.ReplaceAllString(config, "!")
 
 	// find all sections that need to be "eagerly" sent; remove those sections from the "normal"
-	// config, then join all the eager sections into a single string
-	eagerSections := patterns.bannerPattern.FindStringSubmatch(config)
+	// config, then join all the eager sections into a single string. bannerSectionPattern (in
+	// diff.go) matches one banner block at a time so a config with more than one banner doesn't
+	// get swept into a single eager blob spanning everything in between.
+	eagerSections := bannerSectionPattern.FindAllString(config, -1)
 	eagerConfig = strings.Join(eagerSections, "\n")
 
 	for _, section := range eagerSections {
@@ -130,6 +207,8 @@ func (p *EOSCfg) prepareConfigPayloads(config string) (stdConfig, eagerConfig st
 	return config, eagerConfig
 }
 
+const minSessionNameLen = 6
+
 // RegisterConfigSession register a configuration session in EOS.
 func (p *EOSCfg) RegisterConfigSession(sessionName string) error {
 	_, ok := p.conn.PrivilegeLevels[sessionName]
@@ -138,6 +217,10 @@ func (p *EOSCfg) RegisterConfigSession(sessionName string) error {
 		return ErrConfigSessionAlreadyExists
 	}
 
+	if len(sessionName) < minSessionNameLen {
+		return fmt.Errorf("%w: %q", ErrInvalidConfigSessionName, sessionName)
+	}
+
 	sessionPrompt := regexp.QuoteMeta(sessionName[:6])
 	sessionPromptPattern := fmt.Sprintf(
 		`(?im)^[\w.\-@()/:\s]{1,63}\(config\-s\-%s[\w.\-@_/:]{0,32}\)#\s?$`,
@@ -210,23 +293,300 @@ func (p *EOSCfg) LoadConfig(
 
 	stdConfig, eagerConfig := p.prepareConfigPayloads(config)
 
+	if err := p.ensureConfigSession(); err != nil {
+		return nil, err
+	}
+
+	return p.loadConfig(stdConfig, eagerConfig, replace)
+}
+
+// DiffConfig diff a candidate configuration against a source datastore. The candidate is loaded
+// into the configuration session (a new session is opened if one is not already in progress) and
+// the device is asked to compute its own diff; that raw output is returned alongside a
+// side-by-side and a unified diff computed client side. The session is left open on success so
+// that a caller can follow up with CommitConfig, and aborted if any step fails.
+func (p *EOSCfg) DiffConfig(source, candidate string) (*DiffResponse, []*base.Response, error) {
+	var scrapliResponses []*base.Response
+
+	if _, err := p.getConfigCommand(source); err != nil {
+		return nil, scrapliResponses, err
+	}
+
+	if err := p.ensureConfigSession(); err != nil {
+		return nil, scrapliResponses, err
+	}
+
+	stdConfig, eagerConfig := p.prepareConfigPayloads(candidate)
+
+	loadResponses, err := p.loadConfig(stdConfig, eagerConfig, true)
+	scrapliResponses = append(scrapliResponses, loadResponses...)
+
+	if err != nil {
+		if _, abortErr := p.AbortConfig(); abortErr != nil {
+			return nil, scrapliResponses, abortErr
+		}
+
+		return nil, scrapliResponses, err
+	}
+
+	diffCommand := "show session-config diffs"
+
+	if source == "startup" {
+		diffCommand = fmt.Sprintf(
+			"show session-config named %s diffs against startup-config",
+			p.configSessionName,
+		)
+	}
+
+	deviceDiffResult, err := p.conn.SendCommand(
+		diffCommand,
+		base.WithDesiredPrivilegeLevel(p.configSessionName),
+	)
+	if err != nil {
+		if _, abortErr := p.AbortConfig(); abortErr != nil {
+			return nil, scrapliResponses, abortErr
+		}
+
+		return nil, scrapliResponses, err
+	}
+
+	if deviceDiffResult.Failed {
+		if _, abortErr := p.AbortConfig(); abortErr != nil {
+			return nil, scrapliResponses, abortErr
+		}
+
+		return nil, scrapliResponses, fmt.Errorf("%w: %s", ErrConfigCommandFailed, diffCommand)
+	}
+
+	scrapliResponses = append(scrapliResponses, deviceDiffResult)
+
+	sourceConfig, sourceResponses, err := p.GetConfig(source)
+	scrapliResponses = append(scrapliResponses, sourceResponses...)
+
+	if err != nil {
+		if _, abortErr := p.AbortConfig(); abortErr != nil {
+			return nil, scrapliResponses, abortErr
+		}
+
+		return nil, scrapliResponses, err
+	}
+
+	sideBySideDiff, unifiedDiff := buildConfigDiff(sourceConfig, candidate)
+
+	return &DiffResponse{
+		DeviceDiff:     deviceDiffResult.Result,
+		SideBySideDiff: sideBySideDiff,
+		UnifiedDiff:    unifiedDiff,
+	}, scrapliResponses, nil
+}
+
+// CommitConfig commits the configuration staged in the current session, either
+// unconditionally or, when WithCommitTimer is supplied, with an automatic
+// rollback timer that requires a follow-up ConfirmCommit to make permanent.
+func (p *EOSCfg) CommitConfig(opts ...CommitOption) ([]*base.Response, error) {
+	var scrapliResponses []*base.Response
+
 	if p.configSessionName == "" {
-		p.configSessionName = fmt.Sprintf("scrapli_cfg_%d", time.Now().Unix())
+		return scrapliResponses, ErrNoConfigSession
+	}
 
-		logging.LogDebug(
-			FormatLogMessage(
-				p.conn,
-				"debug",
-				fmt.Sprintf("configuration session name will be %s", p.configSessionName),
-			),
+	options, err := applyCommitOptions(opts...)
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	err = p.conn.AcquirePriv("privilege_exec")
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	commitCommand := fmt.Sprintf("configure session %s commit", p.configSessionName)
+
+	if options.Timer > 0 {
+		commitCommand = fmt.Sprintf(
+			"configure session %s commit timer %s",
+			p.configSessionName,
+			formatCommitTimer(options.Timer),
 		)
+	}
 
-		err := p.RegisterConfigSession(p.configSessionName)
+	if options.Comment != "" {
+		commitCommand = fmt.Sprintf("%s comment %s", commitCommand, strconv.Quote(options.Comment))
+	}
+
+	commitResult, err := p.conn.SendConfig(
+		commitCommand,
+		base.WithDesiredPrivilegeLevel("privilege_exec"),
+	)
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	if commitResult.Failed {
+		return scrapliResponses, fmt.Errorf("%w: %s", ErrConfigCommandFailed, commitCommand)
+	}
+
+	scrapliResponses = append(scrapliResponses, commitResult)
+
+	if options.Timer <= 0 {
+		p.ClearConfigSession()
+	}
+
+	return scrapliResponses, nil
+}
+
+// ConfirmCommit confirms a commit that was staged with WithCommitTimer, preventing
+// the device from automatically rolling it back.
+func (p *EOSCfg) ConfirmCommit() ([]*base.Response, error) {
+	var scrapliResponses []*base.Response
+
+	if p.configSessionName == "" {
+		return scrapliResponses, ErrNoConfigSession
+	}
+
+	err := p.conn.AcquirePriv("privilege_exec")
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	confirmCommand := fmt.Sprintf("configure session %s commit", p.configSessionName)
+
+	confirmResult, err := p.conn.SendConfig(
+		confirmCommand,
+		base.WithDesiredPrivilegeLevel("privilege_exec"),
+	)
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	if confirmResult.Failed {
+		return scrapliResponses, fmt.Errorf("%w: %s", ErrConfigCommandFailed, confirmCommand)
+	}
+
+	scrapliResponses = append(scrapliResponses, confirmResult)
+
+	p.ClearConfigSession()
+
+	return scrapliResponses, nil
+}
+
+// CancelPendingCommit aborts a commit that is pending confirmation, rolling the
+// device back to the configuration that was in place before the commit.
+func (p *EOSCfg) CancelPendingCommit() ([]*base.Response, error) {
+	var scrapliResponses []*base.Response
+
+	if p.configSessionName == "" {
+		return scrapliResponses, ErrNoConfigSession
+	}
+
+	err := p.conn.AcquirePriv("privilege_exec")
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	abortCommand := fmt.Sprintf("configure session %s abort", p.configSessionName)
+
+	abortResult, err := p.conn.SendConfig(
+		abortCommand,
+		base.WithDesiredPrivilegeLevel("privilege_exec"),
+	)
+	if err != nil {
+		return scrapliResponses, err
+	}
+
+	if abortResult.Failed {
+		return scrapliResponses, fmt.Errorf("%w: %s", ErrConfigCommandFailed, abortCommand)
+	}
+
+	scrapliResponses = append(scrapliResponses, abortResult)
+
+	p.ClearConfigSession()
+
+	return scrapliResponses, nil
+}
+
+func (p *EOSCfg) ensureConfigSession() error {
+	if p.configSessionName != "" {
+		return nil
+	}
+
+	p.configSessionName = fmt.Sprintf("scrapli_cfg_%d", time.Now().Unix())
+
+	logging.LogDebug(
+		FormatLogMessage(
+			p.conn,
+			"debug",
+			fmt.Sprintf("configuration session name will be %s", p.configSessionName),
+		),
+	)
+
+	err := p.RegisterConfigSession(p.configSessionName)
+	if err != nil {
+		p.configSessionName = ""
+
+		return err
+	}
+
+	if p.sessionStore != nil {
+		if err := p.sessionStore.Save(p.conn.Host, p.configSessionName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *EOSCfg) loadConfigChunks(chunks []string, replace bool) ([]*base.Response, error) {
+	var scrapliResponses []*base.Response
+
+	for i, chunk := range chunks {
+		stdConfig, eagerConfig := p.prepareConfigPayloads(chunk)
+
+		// only the first chunk should roll back to a clean config when replace is requested -
+		// subsequent chunks build on top of what the earlier chunks already staged.
+		chunkResponses, err := p.loadConfig(stdConfig, eagerConfig, replace && i == 0)
+		scrapliResponses = append(scrapliResponses, chunkResponses...)
+
+		if err != nil {
+			return scrapliResponses, err
+		}
+	}
+
+	return scrapliResponses, nil
+}
+
+// LoadConfigFrom reads a candidate configuration from src and loads it the same way
+// LoadConfig does. Sources that implement ChunkedSource (HTTPSource, in particular) are fed
+// to SendConfig in bounded batches instead of as one giant string. The payload is still read
+// into memory in full before it is split into those batches.
+func (p *EOSCfg) LoadConfigFrom(
+	src Source,
+	replace bool,
+	options ...LoadOption,
+) ([]*base.Response, error) {
+	// options are unused for eos
+	_ = options
+
+	if err := p.ensureConfigSession(); err != nil {
+		return nil, err
+	}
+
+	if chunked, ok := src.(ChunkedSource); ok {
+		chunks, err := chunked.ReadChunks(context.Background())
 		if err != nil {
 			return nil, err
 		}
+
+		return p.loadConfigChunks(chunks, replace)
+	}
+
+	config, err := src.Read(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
+	stdConfig, eagerConfig := p.prepareConfigPayloads(config)
+
 	return p.loadConfig(stdConfig, eagerConfig, replace)
 }
 
@@ -246,5 +606,7 @@ func (p *EOSCfg) AbortConfig() ([]*base.Response, error) {
 
 	p.conn.CurrentPriv = "privilege_exec"
 
-	return nil, nil
+	p.ClearConfigSession()
+
+	return scrapliResponses, nil
 }
@@ -0,0 +1,88 @@
+package cfg
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/scrapli/scrapligo/driver/base"
+	"github.com/scrapli/scrapligo/driver/network"
+)
+
+func TestRegisterConfigSessionEscalateDeescalate(t *testing.T) {
+	conn := &network.Driver{PrivilegeLevels: map[string]*base.PrivilegeLevel{}}
+	p := &EOSCfg{conn: conn}
+
+	sessionName := "scrapli_cfg_1234567890"
+
+	if err := p.RegisterConfigSession(sessionName); err != nil {
+		t.Fatalf("RegisterConfigSession returned unexpected error: %v", err)
+	}
+
+	level, ok := conn.PrivilegeLevels[sessionName]
+	if !ok {
+		t.Fatalf("RegisterConfigSession did not register a privilege level for %q", sessionName)
+	}
+
+	if level.PreviousPriv != "privilege_exec" {
+		t.Errorf("PreviousPriv = %q, want %q", level.PreviousPriv, "privilege_exec")
+	}
+
+	wantEscalate := "configure session " + sessionName
+	if level.Escalate != wantEscalate {
+		t.Errorf("Escalate = %q, want %q", level.Escalate, wantEscalate)
+	}
+
+	if level.Deescalate != "end" {
+		t.Errorf("Deescalate = %q, want %q", level.Deescalate, "end")
+	}
+
+	promptPattern := regexp.MustCompile(level.Pattern)
+
+	if !promptPattern.MatchString("switch1(config-s-scrapl)#") {
+		t.Errorf("Pattern %q did not match the expected session prompt", level.Pattern)
+	}
+}
+
+func TestRegisterConfigSessionAlreadyExists(t *testing.T) {
+	conn := &network.Driver{PrivilegeLevels: map[string]*base.PrivilegeLevel{}}
+	p := &EOSCfg{conn: conn}
+
+	sessionName := "scrapli_cfg_1234567890"
+
+	if err := p.RegisterConfigSession(sessionName); err != nil {
+		t.Fatalf("RegisterConfigSession returned unexpected error: %v", err)
+	}
+
+	err := p.RegisterConfigSession(sessionName)
+	if !errors.Is(err, ErrConfigSessionAlreadyExists) {
+		t.Fatalf("RegisterConfigSession error = %v, want ErrConfigSessionAlreadyExists", err)
+	}
+}
+
+func TestPrepareConfigPayloadsKeepsStanzasBetweenMultipleBanners(t *testing.T) {
+	p := &EOSCfg{}
+
+	config := "banner motd\nMOTD text\nEOF\n!\n" +
+		"interface Ethernet1\n   description uplink\n!\n" +
+		"banner login\nWelcome\nEOF\n!\n" +
+		"interface Ethernet2\n   shutdown\n"
+
+	stdConfig, eagerConfig := p.prepareConfigPayloads(config)
+
+	if !strings.Contains(stdConfig, "interface Ethernet1") {
+		t.Errorf(
+			"stdConfig = %q, want it to still contain the stanza between the two banners",
+			stdConfig,
+		)
+	}
+
+	if !strings.Contains(stdConfig, "interface Ethernet2") {
+		t.Errorf("stdConfig = %q, want it to still contain interface Ethernet2", stdConfig)
+	}
+
+	if !strings.Contains(eagerConfig, "banner motd") || !strings.Contains(eagerConfig, "banner login") {
+		t.Errorf("eagerConfig = %q, want both banner blocks", eagerConfig)
+	}
+}
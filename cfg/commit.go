@@ -0,0 +1,70 @@
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoConfigSession is returned when an operation that requires an in-progress
+// configuration session (such as CommitConfig or ConfirmCommit) is called before
+// LoadConfig has opened one.
+var ErrNoConfigSession = errors.New("no configuration session is currently in progress")
+
+// CommitOption modifies the behavior of Platform.CommitConfig.
+type CommitOption func(*CommitOptions) error
+
+// CommitOptions holds the options applied by the CommitOption functions passed to
+// Platform.CommitConfig.
+type CommitOptions struct {
+	// Timer, when positive, stages the commit with a confirm timer rather than
+	// committing unconditionally; the device automatically rolls back if the commit
+	// is not confirmed via ConfirmCommit before the timer elapses.
+	Timer time.Duration
+	// Comment attaches an operator supplied comment to the commit.
+	Comment string
+}
+
+// WithCommitTimer stages the commit with an automatic rollback timer instead of
+// committing unconditionally; the change is only made permanent if ConfirmCommit is
+// called before d elapses.
+func WithCommitTimer(d time.Duration) CommitOption {
+	return func(o *CommitOptions) error {
+		o.Timer = d
+
+		return nil
+	}
+}
+
+// WithCommitComment attaches a comment to the commit.
+func WithCommitComment(comment string) CommitOption {
+	return func(o *CommitOptions) error {
+		o.Comment = comment
+
+		return nil
+	}
+}
+
+func applyCommitOptions(opts ...CommitOption) (*CommitOptions, error) {
+	options := &CommitOptions{}
+
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	return options, nil
+}
+
+// formatCommitTimer renders d in the hh:mm:ss form EOS expects for
+// "configure session <name> commit timer <hh:mm:ss>".
+func formatCommitTimer(d time.Duration) string {
+	total := int(d.Seconds())
+
+	hours := total / 3600
+	minutes := (total % 3600) / 60
+	seconds := total % 60
+
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
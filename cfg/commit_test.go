@@ -0,0 +1,57 @@
+package cfg
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormatCommitTimer(t *testing.T) {
+	cases := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{"zero", 0, "00:00:00"},
+		{"under a minute", 45 * time.Second, "00:00:45"},
+		{"exactly one hour", time.Hour, "01:00:00"},
+		{"over a day", 25*time.Hour + 2*time.Minute + 3*time.Second, "25:02:03"},
+		{"truncates fractional seconds", 90*time.Second + 500*time.Millisecond, "00:01:30"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatCommitTimer(tc.d); got != tc.want {
+				t.Errorf("formatCommitTimer(%s) = %q, want %q", tc.d, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyCommitOptions(t *testing.T) {
+	options, err := applyCommitOptions(WithCommitTimer(time.Minute), WithCommitComment("rollout"))
+	if err != nil {
+		t.Fatalf("applyCommitOptions returned unexpected error: %v", err)
+	}
+
+	if options.Timer != time.Minute {
+		t.Errorf("Timer = %s, want %s", options.Timer, time.Minute)
+	}
+
+	if options.Comment != "rollout" {
+		t.Errorf("Comment = %q, want %q", options.Comment, "rollout")
+	}
+}
+
+func TestApplyCommitOptionsPropagatesError(t *testing.T) {
+	wantErr := errors.New("bad option")
+
+	badOption := func(*CommitOptions) error {
+		return wantErr
+	}
+
+	_, err := applyCommitOptions(badOption)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("applyCommitOptions error = %v, want %v", err, wantErr)
+	}
+}
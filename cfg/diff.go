@@ -0,0 +1,219 @@
+package cfg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// bannerSectionPattern matches a single banner/EOF block non-greedily so that a config with
+// more than one banner (e.g. "banner motd" and "banner login") yields one match per banner
+// instead of a greedy pattern spanning from the first "banner" to the last "EOF" in the
+// document. Both splitConfigSections and prepareConfigPayloads rely on this.
+var bannerSectionPattern = regexp.MustCompile(`(?ims)^banner\s.*?\nEOF$`)
+
+// DiffResponse carries the result of a Platform.DiffConfig call: the raw
+// device-side diff plus a side-by-side and a unified diff computed client
+// side by comparing the source and candidate configurations section by
+// section.
+type DiffResponse struct {
+	// DeviceDiff is the raw output the device produced while computing the diff.
+	DeviceDiff string
+	// SideBySideDiff is a client side computed side-by-side (source | candidate) diff.
+	SideBySideDiff string
+	// UnifiedDiff is a client side computed unified diff between source and candidate.
+	UnifiedDiff string
+}
+
+type diffOp struct {
+	kind string
+	text string
+}
+
+const (
+	diffOpEqual  = "equal"
+	diffOpAdd    = "add"
+	diffOpRemove = "remove"
+)
+
+// splitConfigSections splits a config into its top level stanzas, pulling out
+// banner/eager sections first so they are diffed as a single unit rather than
+// being chopped up by the "!" separators that terminate normal stanzas -
+// this mirrors the eager/banner handling in prepareConfigPayloads.
+func splitConfigSections(config string) []string {
+	eagerSections := bannerSectionPattern.FindAllString(config, -1)
+
+	remaining := config
+	for _, eagerSection := range eagerSections {
+		remaining = strings.Replace(remaining, eagerSection, "!", 1)
+	}
+
+	var sections []string
+
+	for _, section := range strings.Split(remaining, "!") {
+		section = strings.Trim(section, "\n")
+		if section == "" {
+			continue
+		}
+
+		sections = append(sections, section)
+	}
+
+	sections = append(sections, eagerSections...)
+
+	return sections
+}
+
+// sectionKey returns the top level line of a section, used to match up
+// corresponding stanzas between the source and candidate configs.
+func sectionKey(section string) string {
+	line, _, _ := strings.Cut(strings.TrimLeft(section, "\n"), "\n")
+
+	return strings.TrimSpace(line)
+}
+
+func splitSectionLines(section string) []string {
+	if section == "" {
+		return nil
+	}
+
+	return strings.Split(strings.Trim(section, "\n"), "\n")
+}
+
+// lcsLines computes a line-oriented diff of a and b using the standard
+// longest-common-subsequence backtrack.
+func lcsLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, n+m)
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{diffOpEqual, a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			ops = append(ops, diffOp{diffOpRemove, a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{diffOpAdd, b[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{diffOpRemove, a[i]})
+	}
+
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{diffOpAdd, b[j]})
+	}
+
+	return ops
+}
+
+func unifiedDiffFromOps(ops []diffOp) string {
+	var b strings.Builder
+
+	for _, op := range ops {
+		switch op.kind {
+		case diffOpAdd:
+			b.WriteString("+" + op.text + "\n")
+		case diffOpRemove:
+			b.WriteString("-" + op.text + "\n")
+		default:
+			b.WriteString(" " + op.text + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+const sideBySideColumnWidth = 60
+
+func sideBySideFromOps(ops []diffOp) string {
+	var b strings.Builder
+
+	for _, op := range ops {
+		switch op.kind {
+		case diffOpAdd:
+			fmt.Fprintf(&b, "%-*s | %s\n", sideBySideColumnWidth, "", op.text)
+		case diffOpRemove:
+			fmt.Fprintf(&b, "%-*s | %s\n", sideBySideColumnWidth, op.text, "")
+		default:
+			fmt.Fprintf(&b, "%-*s | %s\n", sideBySideColumnWidth, op.text, op.text)
+		}
+	}
+
+	return b.String()
+}
+
+// buildConfigDiff splits source and candidate into their top level stanzas,
+// matches corresponding stanzas up by key, and runs a line oriented diff on
+// any stanza that changed, returning a side-by-side and a unified rendering
+// of the result.
+func buildConfigDiff(source, candidate string) (sideBySideDiff, unifiedDiff string) {
+	sourceByKey := make(map[string]string)
+	candidateByKey := make(map[string]string)
+
+	var order []string
+
+	seen := make(map[string]bool)
+
+	for _, section := range splitConfigSections(source) {
+		key := sectionKey(section)
+		sourceByKey[key] = section
+
+		if !seen[key] {
+			seen[key] = true
+			order = append(order, key)
+		}
+	}
+
+	for _, section := range splitConfigSections(candidate) {
+		key := sectionKey(section)
+		candidateByKey[key] = section
+
+		if !seen[key] {
+			seen[key] = true
+			order = append(order, key)
+		}
+	}
+
+	var sideBySideBuilder, unifiedBuilder strings.Builder
+
+	for _, key := range order {
+		sourceSection := sourceByKey[key]
+		candidateSection := candidateByKey[key]
+
+		if sourceSection == candidateSection {
+			continue
+		}
+
+		ops := lcsLines(splitSectionLines(sourceSection), splitSectionLines(candidateSection))
+
+		unifiedBuilder.WriteString(unifiedDiffFromOps(ops))
+		sideBySideBuilder.WriteString(sideBySideFromOps(ops))
+	}
+
+	return sideBySideBuilder.String(), unifiedBuilder.String()
+}
@@ -0,0 +1,100 @@
+package cfg
+
+import "testing"
+
+func TestBuildConfigDiffUnchangedSectionProducesNoOutput(t *testing.T) {
+	source := "interface Ethernet1\n   description uplink\n"
+	candidate := source
+
+	sideBySide, unified := buildConfigDiff(source, candidate)
+
+	if sideBySide != "" {
+		t.Errorf("sideBySide = %q, want empty for an unchanged config", sideBySide)
+	}
+
+	if unified != "" {
+		t.Errorf("unified = %q, want empty for an unchanged config", unified)
+	}
+}
+
+func TestBuildConfigDiffChangedLine(t *testing.T) {
+	source := "interface Ethernet1\n   description uplink\n"
+	candidate := "interface Ethernet1\n   description core\n"
+
+	_, unified := buildConfigDiff(source, candidate)
+
+	wantRemove := "-   description uplink\n"
+	wantAdd := "+   description core\n"
+
+	if !containsLine(unified, wantRemove) {
+		t.Errorf("unified diff %q missing removed line %q", unified, wantRemove)
+	}
+
+	if !containsLine(unified, wantAdd) {
+		t.Errorf("unified diff %q missing added line %q", unified, wantAdd)
+	}
+}
+
+func containsLine(haystack, line string) bool {
+	for i := 0; i+len(line) <= len(haystack); i++ {
+		if haystack[i:i+len(line)] == line {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestSplitConfigSectionsKeepsBannerWhole(t *testing.T) {
+	config := "interface Ethernet1\n   description uplink\n!\n" +
+		"banner login\nWelcome\nEOF\n!\n" +
+		"interface Ethernet2\n   shutdown\n"
+
+	sections := splitConfigSections(config)
+
+	var bannerSections int
+
+	for _, section := range sections {
+		if section == "banner login\nWelcome\nEOF" {
+			bannerSections++
+		}
+	}
+
+	if bannerSections != 1 {
+		t.Fatalf("expected exactly one whole banner section among %v, got %d", sections, bannerSections)
+	}
+}
+
+func TestSplitConfigSectionsHandlesMultipleBanners(t *testing.T) {
+	config := "banner motd\nMOTD text\nEOF\n!\n" +
+		"interface Ethernet1\n   description uplink\n!\n" +
+		"banner login\nWelcome\nEOF\n!\n" +
+		"interface Ethernet2\n   shutdown\n"
+
+	sections := splitConfigSections(config)
+
+	wantSections := []string{
+		"banner motd\nMOTD text\nEOF",
+		"interface Ethernet1\n   description uplink",
+		"banner login\nWelcome\nEOF",
+		"interface Ethernet2\n   shutdown",
+	}
+
+	for _, want := range wantSections {
+		var found int
+
+		for _, section := range sections {
+			if section == want {
+				found++
+			}
+		}
+
+		if found != 1 {
+			t.Errorf("expected exactly one section %q among %v, got %d", want, sections, found)
+		}
+	}
+
+	if len(sections) != len(wantSections) {
+		t.Fatalf("expected %d sections, got %d: %v", len(wantSections), len(sections), sections)
+	}
+}
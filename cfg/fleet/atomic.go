@@ -0,0 +1,93 @@
+package fleet
+
+import (
+	"github.com/scrapli/scrapligo/cfg"
+	"github.com/scrapli/scrapligo/driver/base"
+)
+
+// AtomicResult carries the outcome of a device's stage in a LoadConfigAtomic call, along
+// with the diff that was computed for it before the fleet-wide commit/abort decision.
+type AtomicResult struct {
+	FleetResult
+	Diff *cfg.DiffResponse
+	// Committed is true if this device's staged change was committed; false means it was
+	// not: either this device or another device in the fleet failed to stage/diff, or the
+	// commit itself failed on this device (see Err).
+	Committed bool
+}
+
+// LoadConfigAtomic stages candidate on every device inside a configuration session (via
+// DiffConfig, which loads the candidate before computing its diff) and only commits if every
+// device staged and diffed successfully - if any device fails either step, it aborts the
+// loaded candidate on every device that is still staged instead (a device whose DiffConfig
+// returned an error has already aborted itself). The commit step is a plain CommitConfig
+// fan-out: if the commit fails on some devices, the devices where it succeeded stay
+// committed.
+func (f *Fleet) LoadConfigAtomic(source, candidate string) map[string]AtomicResult {
+	payloadResults := f.dispatchPayload(
+		f.devices,
+		func(c *cfg.Cfg) (any, []*base.Response, error) {
+			return c.Platform.DiffConfig(source, candidate)
+		},
+	)
+
+	results := make(map[string]AtomicResult, len(payloadResults))
+	for name, result := range payloadResults {
+		diff, _ := result.Payload.(*cfg.DiffResponse)
+
+		results[name] = AtomicResult{
+			FleetResult: result.FleetResult,
+			Diff:        diff,
+		}
+	}
+
+	allStaged := true
+
+	var stagedDevices []Device
+
+	for _, device := range f.devices {
+		if results[device.Name].Err != nil {
+			allStaged = false
+
+			continue
+		}
+
+		stagedDevices = append(stagedDevices, device)
+	}
+
+	// A device whose DiffConfig already failed has already self-aborted and cleared its
+	// configuration session (see EOSCfg.DiffConfig), so only the devices that are still
+	// staged need finalizing here - reattempting AbortConfig against an already-cleared
+	// session would just acquire an empty privilege level for nothing. A device that hit
+	// ErrDeviceTimeout is skipped too: its DiffConfig keeps running in the background and
+	// is not aborted here, so it may be left with the candidate staged.
+	finalize := func() map[string]FleetResult {
+		return f.dispatchDevices(stagedDevices, func(c *cfg.Cfg) ([]*base.Response, error) {
+			return c.Platform.AbortConfig()
+		})
+	}
+	if allStaged {
+		finalize = func() map[string]FleetResult {
+			return f.CommitConfig()
+		}
+	}
+
+	finalizeResults := finalize()
+
+	for name, result := range results {
+		finalizeResult := finalizeResults[name]
+
+		result.Responses = append(result.Responses, finalizeResult.Responses...)
+		result.Elapsed += finalizeResult.Elapsed
+
+		if result.Err == nil {
+			result.Err = finalizeResult.Err
+		}
+
+		result.Committed = allStaged && finalizeResult.Err == nil
+
+		results[name] = result
+	}
+
+	return results
+}
@@ -0,0 +1,49 @@
+package fleet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scrapli/scrapligo/cfg"
+)
+
+func TestLoadConfigAtomicAbortsOnlyStagedDevicesOnFailure(t *testing.T) {
+	wantErr := errors.New("diff failed")
+
+	staged := &fakePlatform{diffResp: &cfg.DiffResponse{UnifiedDiff: "staged diff"}}
+	failed := &fakePlatform{diffErr: wantErr}
+
+	f := NewFleet([]Device{
+		fakeDevice("staged", staged),
+		fakeDevice("failed", failed),
+	})
+
+	results := f.LoadConfigAtomic("running", "candidate")
+
+	if staged.abortCalled != 1 {
+		t.Errorf("staged device AbortConfig called %d times, want 1", staged.abortCalled)
+	}
+
+	if failed.abortCalled != 0 {
+		t.Errorf(
+			"failed device AbortConfig called %d times, want 0 - it already self-aborted in DiffConfig",
+			failed.abortCalled,
+		)
+	}
+
+	if staged.commitCalled != 0 || failed.commitCalled != 0 {
+		t.Errorf(
+			"CommitConfig called when a device failed to stage: staged=%d failed=%d, want 0/0",
+			staged.commitCalled,
+			failed.commitCalled,
+		)
+	}
+
+	if results["staged"].Committed {
+		t.Errorf("staged device Committed = true, want false since the fleet-wide load failed")
+	}
+
+	if !errors.Is(results["failed"].Err, wantErr) {
+		t.Errorf("failed device Err = %v, want %v", results["failed"].Err, wantErr)
+	}
+}
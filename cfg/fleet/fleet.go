@@ -0,0 +1,362 @@
+// Package fleet fans cfg operations out across many devices concurrently, the way a
+// central orchestrator drives a pool of managed workers instead of each caller
+// reimplementing the goroutine and error-aggregation dance on top of cfg.Platform itself.
+package fleet
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/scrapli/scrapligo/cfg"
+	"github.com/scrapli/scrapligo/driver/base"
+	"github.com/scrapli/scrapligo/driver/network"
+)
+
+// ErrDeviceTimeout is returned for a device whose operation did not complete within the
+// Fleet's configured per-device timeout.
+var ErrDeviceTimeout = errors.New("device operation timed out")
+
+// ErrDeviceBusy is returned for a device whose previous operation timed out and is still
+// running in the background - network.Driver has no context-aware cancellation, so runOp
+// cannot kill that goroutine outright, and it must not be allowed to run concurrently with a
+// new operation against the same cached cfg.Cfg.
+var ErrDeviceBusy = errors.New("device has an operation still in flight from a previous timeout")
+
+// PlatformFactory builds the cfg.Cfg instance for a single managed device connection, for
+// example cfg.NewEOSCfg.
+type PlatformFactory func(conn *network.Driver) (*cfg.Cfg, error)
+
+// Device pairs a connected driver with the factory used to build its cfg.Cfg instance.
+type Device struct {
+	// Name identifies the device in Fleet results; typically the device's host.
+	Name            string
+	Conn            *network.Driver
+	PlatformFactory PlatformFactory
+}
+
+// FleetResult carries the outcome of a single device's operation within a Fleet call.
+type FleetResult struct {
+	Responses []*base.Response
+	Err       error
+	Elapsed   time.Duration
+}
+
+// GetConfigResult extends FleetResult with the configuration retrieved from the device.
+type GetConfigResult struct {
+	FleetResult
+	Config string
+}
+
+// DiffConfigResult extends FleetResult with the computed diff for the device.
+type DiffConfigResult struct {
+	FleetResult
+	Diff *cfg.DiffResponse
+}
+
+// Option configures a Fleet.
+type Option func(*Fleet)
+
+// WithWorkerPoolSize caps how many devices are operated on concurrently; it defaults to
+// operating on every device at once when unset.
+func WithWorkerPoolSize(n int) Option {
+	return func(f *Fleet) {
+		f.workerPoolSize = n
+	}
+}
+
+// WithDeviceTimeout bounds how long a single device's operation may run before it is
+// abandoned and reported back as ErrDeviceTimeout.
+func WithDeviceTimeout(d time.Duration) Option {
+	return func(f *Fleet) {
+		f.deviceTimeout = d
+	}
+}
+
+// Fleet operates on a fixed set of devices, fanning cfg.Platform operations out across all
+// of them concurrently.
+type Fleet struct {
+	devices        []Device
+	workerPoolSize int
+	deviceTimeout  time.Duration
+
+	cfgMu sync.Mutex
+	cfgs  map[string]*cfg.Cfg
+
+	busyMu sync.Mutex
+	busy   map[string]bool
+}
+
+// NewFleet returns a Fleet that operates on devices.
+func NewFleet(devices []Device, opts ...Option) *Fleet {
+	f := &Fleet{
+		devices:        devices,
+		workerPoolSize: len(devices),
+		cfgs:           make(map[string]*cfg.Cfg, len(devices)),
+		busy:           make(map[string]bool, len(devices)),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if f.workerPoolSize <= 0 {
+		f.workerPoolSize = len(devices)
+	}
+
+	return f
+}
+
+// cfgFor returns device's *cfg.Cfg, building it via device.PlatformFactory and caching it
+// the first time it is needed. Every subsequent operation against device reuses that same
+// instance, which matters because the cfg.Platform it wraps carries session state (e.g. the
+// in-progress configuration session name) across calls - LoadConfig staging a change and a
+// later CommitConfig/AbortConfig finalizing it must see the same instance, not a fresh one.
+func (f *Fleet) cfgFor(device Device) (*cfg.Cfg, error) {
+	f.cfgMu.Lock()
+	defer f.cfgMu.Unlock()
+
+	if c, ok := f.cfgs[device.Name]; ok {
+		return c, nil
+	}
+
+	c, err := device.PlatformFactory(device.Conn)
+	if err != nil {
+		return nil, err
+	}
+
+	f.cfgs[device.Name] = c
+
+	return c, nil
+}
+
+// acquireDevice marks device as having an operation in flight against its cached cfg.Cfg,
+// refusing to do so if a prior timed-out operation against it hasn't finished yet.
+func (f *Fleet) acquireDevice(name string) error {
+	f.busyMu.Lock()
+	defer f.busyMu.Unlock()
+
+	if f.busy[name] {
+		return fmt.Errorf("%w: %s", ErrDeviceBusy, name)
+	}
+
+	f.busy[name] = true
+
+	return nil
+}
+
+// releaseDevice clears the in-flight marker set by acquireDevice.
+func (f *Fleet) releaseDevice(name string) {
+	f.busyMu.Lock()
+	defer f.busyMu.Unlock()
+
+	delete(f.busy, name)
+}
+
+// runOp runs op against device's cfg.Cfg, bounding it by the Fleet's device timeout if one
+// is configured, and returns whatever extra payload op produces (e.g. a fetched config or a
+// computed diff) alongside the usual responses/error. The payload travels back solely over
+// the done channel rather than a variable op's caller closes over, so a caller that gives up
+// waiting on a timeout never reads a value the abandoned goroutine is concurrently writing.
+//
+// If a timeout fires, the underlying op keeps running in the background - network.Driver has
+// no context-aware cancellation - so device is left marked busy until that goroutine
+// finishes, and any op attempted against it in the meantime fails fast with ErrDeviceBusy
+// instead of running concurrently against the same cached cfg.Cfg.
+func (f *Fleet) runOp(
+	device Device,
+	op func(c *cfg.Cfg) (any, []*base.Response, error),
+) (any, []*base.Response, error) {
+	if err := f.acquireDevice(device.Name); err != nil {
+		return nil, nil, err
+	}
+
+	c, err := f.cfgFor(device)
+	if err != nil {
+		f.releaseDevice(device.Name)
+
+		return nil, nil, err
+	}
+
+	if f.deviceTimeout <= 0 {
+		defer f.releaseDevice(device.Name)
+
+		return op(c)
+	}
+
+	type opResult struct {
+		payload   any
+		responses []*base.Response
+		err       error
+	}
+
+	done := make(chan opResult, 1)
+
+	go func() {
+		defer f.releaseDevice(device.Name)
+
+		payload, responses, opErr := op(c)
+		done <- opResult{payload, responses, opErr}
+	}()
+
+	select {
+	case result := <-done:
+		return result.payload, result.responses, result.err
+	case <-time.After(f.deviceTimeout):
+		return nil, nil, fmt.Errorf("%w: %s", ErrDeviceTimeout, device.Name)
+	}
+}
+
+// payloadResult is a FleetResult plus whatever extra value op produced, as collected by
+// dispatchPayload - GetConfig, DiffConfig and LoadConfigAtomic each type-assert Payload back
+// to their own concrete result type.
+type payloadResult struct {
+	FleetResult
+	Payload any
+}
+
+// dispatchPayload runs op against devices concurrently, bounded by the Fleet's worker pool
+// size, and collects each device's payload/responses/error/elapsed time into a payloadResult.
+// This is the single fan-out implementation shared by dispatchDevices, GetConfig, DiffConfig
+// and LoadConfigAtomic's staging pass, so a future fix to the concurrency handling (timeouts,
+// cancellation, ...) only needs to land here once.
+func (f *Fleet) dispatchPayload(
+	devices []Device,
+	op func(c *cfg.Cfg) (any, []*base.Response, error),
+) map[string]payloadResult {
+	results := make(map[string]payloadResult, len(devices))
+
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, f.workerPoolSize)
+
+	for _, device := range devices {
+		device := device
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			payload, responses, err := f.runOp(device, op)
+
+			mu.Lock()
+			results[device.Name] = payloadResult{
+				FleetResult: FleetResult{
+					Responses: responses,
+					Err:       err,
+					Elapsed:   time.Since(start),
+				},
+				Payload: payload,
+			}
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// dispatch runs op against every device concurrently, discarding the payload returned by
+// dispatchPayload - used by operations that have nothing to report beyond the FleetResult.
+func (f *Fleet) dispatch(
+	op func(c *cfg.Cfg) ([]*base.Response, error),
+) map[string]FleetResult {
+	return f.dispatchDevices(f.devices, op)
+}
+
+// dispatchDevices is dispatch restricted to the given subset of f.devices - LoadConfigAtomic
+// uses this to abort only the devices that are still staged, rather than every device in the
+// fleet.
+func (f *Fleet) dispatchDevices(
+	devices []Device,
+	op func(c *cfg.Cfg) ([]*base.Response, error),
+) map[string]FleetResult {
+	payloadResults := f.dispatchPayload(devices, func(c *cfg.Cfg) (any, []*base.Response, error) {
+		responses, err := op(c)
+
+		return nil, responses, err
+	})
+
+	results := make(map[string]FleetResult, len(payloadResults))
+	for name, result := range payloadResults {
+		results[name] = result.FleetResult
+	}
+
+	return results
+}
+
+// GetConfig fetches the source datastore's configuration from every device concurrently.
+func (f *Fleet) GetConfig(source string) map[string]GetConfigResult {
+	payloadResults := f.dispatchPayload(
+		f.devices,
+		func(c *cfg.Cfg) (any, []*base.Response, error) {
+			return c.Platform.GetConfig(source)
+		},
+	)
+
+	results := make(map[string]GetConfigResult, len(payloadResults))
+	for name, result := range payloadResults {
+		config, _ := result.Payload.(string)
+
+		results[name] = GetConfigResult{
+			FleetResult: result.FleetResult,
+			Config:      config,
+		}
+	}
+
+	return results
+}
+
+// LoadConfig loads the candidate configuration onto every device concurrently.
+func (f *Fleet) LoadConfig(config string, replace bool) map[string]FleetResult {
+	return f.dispatch(func(c *cfg.Cfg) ([]*base.Response, error) {
+		return c.Platform.LoadConfig(config, replace)
+	})
+}
+
+// CommitConfig commits the previously loaded candidate configuration on every device
+// concurrently.
+func (f *Fleet) CommitConfig(opts ...cfg.CommitOption) map[string]FleetResult {
+	return f.dispatch(func(c *cfg.Cfg) ([]*base.Response, error) {
+		return c.Platform.CommitConfig(opts...)
+	})
+}
+
+// AbortConfig aborts the previously loaded candidate configuration on every device
+// concurrently.
+func (f *Fleet) AbortConfig() map[string]FleetResult {
+	return f.dispatch(func(c *cfg.Cfg) ([]*base.Response, error) {
+		return c.Platform.AbortConfig()
+	})
+}
+
+// DiffConfig diffs the candidate configuration against the source datastore on every
+// device concurrently.
+func (f *Fleet) DiffConfig(source, candidate string) map[string]DiffConfigResult {
+	payloadResults := f.dispatchPayload(
+		f.devices,
+		func(c *cfg.Cfg) (any, []*base.Response, error) {
+			return c.Platform.DiffConfig(source, candidate)
+		},
+	)
+
+	results := make(map[string]DiffConfigResult, len(payloadResults))
+	for name, result := range payloadResults {
+		diff, _ := result.Payload.(*cfg.DiffResponse)
+
+		results[name] = DiffConfigResult{
+			FleetResult: result.FleetResult,
+			Diff:        diff,
+		}
+	}
+
+	return results
+}
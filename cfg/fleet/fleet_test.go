@@ -0,0 +1,172 @@
+package fleet
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scrapli/scrapligo/cfg"
+	"github.com/scrapli/scrapligo/driver/base"
+	"github.com/scrapli/scrapligo/driver/network"
+)
+
+// fakePlatform is a minimal cfg.Platform used to drive Fleet's concurrency handling without
+// talking to a real device.
+type fakePlatform struct {
+	mu sync.Mutex
+
+	getConfigFunc func() (string, []*base.Response, error)
+
+	diffResp     *cfg.DiffResponse
+	diffErr      error
+	abortCalled  int
+	abortErr     error
+	commitCalled int
+}
+
+func (p *fakePlatform) GetVersion() (string, []*base.Response, error) { return "", nil, nil }
+
+func (p *fakePlatform) GetConfig(_ string) (string, []*base.Response, error) {
+	if p.getConfigFunc != nil {
+		return p.getConfigFunc()
+	}
+
+	return "", nil, nil
+}
+
+func (p *fakePlatform) LoadConfig(_ string, _ bool, _ ...cfg.LoadOption) ([]*base.Response, error) {
+	return nil, nil
+}
+
+func (p *fakePlatform) LoadConfigFrom(
+	_ cfg.Source,
+	_ bool,
+	_ ...cfg.LoadOption,
+) ([]*base.Response, error) {
+	return nil, nil
+}
+
+func (p *fakePlatform) AbortConfig() ([]*base.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.abortCalled++
+
+	return nil, p.abortErr
+}
+
+func (p *fakePlatform) DiffConfig(_, _ string) (*cfg.DiffResponse, []*base.Response, error) {
+	return p.diffResp, nil, p.diffErr
+}
+
+func (p *fakePlatform) CommitConfig(_ ...cfg.CommitOption) ([]*base.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.commitCalled++
+
+	return nil, nil
+}
+
+func (p *fakePlatform) ConfirmCommit() ([]*base.Response, error) { return nil, nil }
+
+func fakeDevice(name string, platform *fakePlatform) Device {
+	return Device{
+		Name: name,
+		PlatformFactory: func(_ *network.Driver) (*cfg.Cfg, error) {
+			return &cfg.Cfg{Platform: platform}, nil
+		},
+	}
+}
+
+func TestAcquireDeviceReturnsErrDeviceBusy(t *testing.T) {
+	f := NewFleet(nil)
+
+	if err := f.acquireDevice("sw1"); err != nil {
+		t.Fatalf("first acquireDevice returned unexpected error: %v", err)
+	}
+
+	err := f.acquireDevice("sw1")
+	if !errors.Is(err, ErrDeviceBusy) {
+		t.Fatalf("second acquireDevice error = %v, want ErrDeviceBusy", err)
+	}
+
+	f.releaseDevice("sw1")
+
+	if err := f.acquireDevice("sw1"); err != nil {
+		t.Fatalf("acquireDevice after release returned unexpected error: %v", err)
+	}
+}
+
+func TestRunOpTimeoutDoesNotReadAbandonedPayload(t *testing.T) {
+	block := make(chan struct{})
+	opDone := make(chan struct{})
+
+	platform := &fakePlatform{
+		getConfigFunc: func() (string, []*base.Response, error) {
+			<-block
+
+			defer close(opDone)
+
+			return "late-payload", nil, nil
+		},
+	}
+
+	device := fakeDevice("sw1", platform)
+
+	f := NewFleet([]Device{device}, WithDeviceTimeout(20*time.Millisecond))
+
+	payload, responses, err := f.runOp(device, func(c *cfg.Cfg) (any, []*base.Response, error) {
+		return c.Platform.GetConfig("running")
+	})
+
+	if !errors.Is(err, ErrDeviceTimeout) {
+		t.Fatalf("runOp error = %v, want ErrDeviceTimeout", err)
+	}
+
+	if payload != nil || responses != nil {
+		t.Fatalf(
+			"runOp returned payload %v / responses %v from a timed out op, want nil",
+			payload,
+			responses,
+		)
+	}
+
+	if err := f.acquireDevice(device.Name); !errors.Is(err, ErrDeviceBusy) {
+		t.Fatalf(
+			"acquireDevice while the abandoned op is still running = %v, want ErrDeviceBusy",
+			err,
+		)
+	}
+
+	close(block)
+	<-opDone
+
+	waitUntil(t, time.Second, func() bool {
+		if err := f.acquireDevice(device.Name); err == nil {
+			f.releaseDevice(device.Name)
+
+			return true
+		}
+
+		return false
+	})
+}
+
+// waitUntil polls condition until it returns true or timeout elapses.
+func waitUntil(t *testing.T, timeout time.Duration, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("condition not met within %s", timeout)
+}
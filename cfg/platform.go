@@ -0,0 +1,25 @@
+package cfg
+
+import "github.com/scrapli/scrapligo/driver/base"
+
+// Platform is implemented by each supported device type and is responsible for actually
+// speaking to the device; Cfg wraps a Platform with the source/session bookkeeping that is
+// common across platforms.
+type Platform interface {
+	// GetVersion get the version from the device.
+	GetVersion() (string, []*base.Response, error)
+	// GetConfig get the configuration of a source datastore from the device.
+	GetConfig(source string) (string, []*base.Response, error)
+	// LoadConfig load a candidate configuration.
+	LoadConfig(config string, replace bool, options ...LoadOption) ([]*base.Response, error)
+	// LoadConfigFrom reads a candidate configuration from src and loads it.
+	LoadConfigFrom(src Source, replace bool, options ...LoadOption) ([]*base.Response, error)
+	// AbortConfig abort the loaded candidate configuration.
+	AbortConfig() ([]*base.Response, error)
+	// DiffConfig diff a candidate configuration against a source datastore.
+	DiffConfig(source, candidate string) (*DiffResponse, []*base.Response, error)
+	// CommitConfig commits the configuration staged in the current session.
+	CommitConfig(options ...CommitOption) ([]*base.Response, error)
+	// ConfirmCommit confirms a commit that was staged with a confirm timer.
+	ConfirmCommit() ([]*base.Response, error)
+}
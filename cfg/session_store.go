@@ -0,0 +1,140 @@
+package cfg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SessionStore persists the name of an in-progress configuration session outside of the
+// running process, so a controller that restarts can find and resume the session instead
+// of orphaning it on the device and silently losing any staged-but-uncommitted changes.
+type SessionStore interface {
+	// Save records host's in-progress session name.
+	Save(host, session string) error
+	// Load returns host's in-progress session name, or "" if none is stored.
+	Load(host string) (string, error)
+	// Delete removes any stored session name for host.
+	Delete(host string) error
+}
+
+// WithSessionStore configures the SessionStore used to persist the name of an in-progress
+// configuration session. If store already has a session recorded for this device, that
+// session's prompt pattern is re-registered immediately so it can be resumed rather than
+// leaving it orphaned on the device while a new one gets allocated on the next LoadConfig.
+func WithSessionStore(store SessionStore) Option {
+	return func(p Platform) error {
+		eos, ok := p.(*EOSCfg)
+		if !ok {
+			return nil
+		}
+
+		eos.sessionStore = store
+
+		return eos.resumeConfigSession()
+	}
+}
+
+// FileSessionStore persists session names as one file per host inside Dir.
+type FileSessionStore struct {
+	Dir string
+}
+
+// NewFileSessionStore returns a FileSessionStore that persists session names under dir.
+func NewFileSessionStore(dir string) *FileSessionStore {
+	return &FileSessionStore{Dir: dir}
+}
+
+func sanitizeHostForFilename(host string) string {
+	replacer := strings.NewReplacer("/", "_", ":", "_")
+
+	return replacer.Replace(host)
+}
+
+func (s *FileSessionStore) path(host string) string {
+	return filepath.Join(s.Dir, sanitizeHostForFilename(host)+".session")
+}
+
+// sessionFileContents formats what gets written to a session file: the original,
+// unsanitized host on its own first line, followed by the session name. The host has to be
+// persisted alongside the session rather than recovered from the sanitized filename, since
+// sanitizeHostForFilename is lossy for hosts containing "/" or ":" (IPv6 literals,
+// "host:port" pairs).
+func sessionFileContents(host, session string) string {
+	return host + "\n" + session
+}
+
+// parseSessionFileContents reverses sessionFileContents.
+func parseSessionFileContents(content string) (host, session string) {
+	host, session, _ = strings.Cut(content, "\n")
+
+	return host, strings.TrimSpace(session)
+}
+
+// Save implements SessionStore.
+func (s *FileSessionStore) Save(host, session string) error {
+	if err := os.MkdirAll(s.Dir, 0o750); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(host), []byte(sessionFileContents(host, session)), 0o600)
+}
+
+// Load implements SessionStore.
+func (s *FileSessionStore) Load(host string) (string, error) {
+	content, err := os.ReadFile(s.path(host))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	_, session := parseSessionFileContents(string(content))
+
+	return session, nil
+}
+
+// Delete implements SessionStore.
+func (s *FileSessionStore) Delete(host string) error {
+	err := os.Remove(s.path(host))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// ListPendingSessions returns the host -> session name pairs currently persisted in Dir,
+// giving operators visibility into sessions that may be orphaned on devices after an
+// unclean controller restart.
+func (s *FileSessionStore) ListPendingSessions() (map[string]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+
+		return nil, err
+	}
+
+	pending := make(map[string]string, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".session") {
+			continue
+		}
+
+		content, readErr := os.ReadFile(filepath.Join(s.Dir, entry.Name()))
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		host, session := parseSessionFileContents(string(content))
+		pending[host] = session
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,35 @@
+package cfg
+
+import (
+	"testing"
+)
+
+func TestFileSessionStoreListPendingSessionsPreservesHost(t *testing.T) {
+	store := NewFileSessionStore(t.TempDir())
+
+	hosts := []string{"switch1", "10.0.0.1:8022", "fe80::1"}
+
+	for _, host := range hosts {
+		if err := store.Save(host, "scrapli_cfg_1234567890"); err != nil {
+			t.Fatalf("Save(%q) returned unexpected error: %v", host, err)
+		}
+	}
+
+	pending, err := store.ListPendingSessions()
+	if err != nil {
+		t.Fatalf("ListPendingSessions returned unexpected error: %v", err)
+	}
+
+	for _, host := range hosts {
+		session, ok := pending[host]
+		if !ok {
+			t.Errorf("ListPendingSessions missing entry for host %q; got %v", host, pending)
+
+			continue
+		}
+
+		if session != "scrapli_cfg_1234567890" {
+			t.Errorf("ListPendingSessions[%q] = %q, want %q", host, session, "scrapli_cfg_1234567890")
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package cfg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// ErrSourceFetchFailed is returned when a Source is unable to retrieve its configuration
+// payload, for example an HTTPSource receiving a non-200 response.
+var ErrSourceFetchFailed = errors.New("failed fetching candidate configuration")
+
+// Source supplies a candidate configuration to Platform.LoadConfigFrom, decoupling the
+// in-memory string LoadConfig expects from wherever the config actually lives - a file on
+// disk, a rendered template, or an artifact stored on a remote server.
+type Source interface {
+	// Read returns the candidate configuration payload in full.
+	Read(ctx context.Context) (string, error)
+}
+
+// ChunkedSource is implemented by Source values that can stream their payload as a series
+// of bounded pieces instead of returning it all at once. LoadConfigFrom uses this to feed
+// large configs to SendConfig in batches rather than building and sending one giant string.
+type ChunkedSource interface {
+	Source
+
+	// ReadChunks returns the candidate configuration split into bounded, independently
+	// sendable pieces.
+	ReadChunks(ctx context.Context) ([]string, error)
+}
+
+// FileSource reads a candidate configuration from a file on disk.
+type FileSource struct {
+	Path string
+}
+
+// NewFileSource returns a Source that reads the candidate configuration from path.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{Path: path}
+}
+
+// Read implements Source.
+func (s *FileSource) Read(_ context.Context) (string, error) {
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+const defaultHTTPSourceLinesPerChunk = 500
+
+// HTTPSource fetches a candidate configuration from a URL. It implements ChunkedSource so
+// large configs can be fed to SendConfig in bounded batches instead of as one giant string.
+type HTTPSource struct {
+	URL    string
+	Client *http.Client
+	// LinesPerChunk controls how many lines ReadChunks groups into a single chunk; it
+	// defaults to defaultHTTPSourceLinesPerChunk when unset.
+	LinesPerChunk int
+}
+
+// NewHTTPSource returns a Source that fetches the candidate configuration from url using
+// http.DefaultClient.
+func NewHTTPSource(url string) *HTTPSource {
+	return &HTTPSource{
+		URL:    url,
+		Client: http.DefaultClient,
+	}
+}
+
+// Read implements Source by joining the chunked response back into a single string.
+func (s *HTTPSource) Read(ctx context.Context) (string, error) {
+	chunks, err := s.ReadChunks(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(chunks, "\n"), nil
+}
+
+// ReadChunks implements ChunkedSource. Boundaries between chunks always fall on a whole
+// top-level stanza (the sections splitConfigSections splits a config into, with banner/eager
+// blocks kept whole as in prepareConfigPayloads), never in the middle of one - a banner/eager section that happened
+// to straddle an arbitrary line-count boundary would be split across two chunks and would no
+// longer match the banner pattern in either half, so it would get sent as ordinary config
+// instead of eagerly.
+func (s *HTTPSource) ReadChunks(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"%w: unexpected status code %d fetching %s",
+			ErrSourceFetchFailed,
+			resp.StatusCode,
+			s.URL,
+		)
+	}
+
+	linesPerChunk := s.LinesPerChunk
+	if linesPerChunk <= 0 {
+		linesPerChunk = defaultHTTPSourceLinesPerChunk
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var body strings.Builder
+
+	for scanner.Scan() {
+		body.WriteString(scanner.Text())
+		body.WriteString("\n")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return groupConfigSectionsIntoChunks(body.String(), linesPerChunk), nil
+}
+
+// groupConfigSectionsIntoChunks splits config into its top-level stanzas and packs them back
+// together into chunks of roughly linesPerChunk lines apiece, without ever splitting a single
+// stanza (banner/eager sections included) across two chunks.
+func groupConfigSectionsIntoChunks(config string, linesPerChunk int) []string {
+	sections := splitConfigSections(config)
+
+	var chunks []string
+
+	var current []string
+
+	currentLines := 0
+
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, "\n!\n"))
+			current = nil
+			currentLines = 0
+		}
+	}
+
+	for _, section := range sections {
+		sectionLines := len(splitSectionLines(section))
+
+		if currentLines > 0 && currentLines+sectionLines > linesPerChunk {
+			flush()
+		}
+
+		current = append(current, section)
+		currentLines += sectionLines
+	}
+
+	flush()
+
+	return chunks
+}
+
+// TemplateSource renders a candidate configuration from a Go text/template, letting
+// callers drive per-device configs from a shared template and a map of variables instead
+// of pre-rendering configs outside of scrapligo.
+type TemplateSource struct {
+	Template  *template.Template
+	Variables map[string]any
+}
+
+// NewTemplateSource returns a Source that renders tmpl with the supplied variables.
+func NewTemplateSource(tmpl *template.Template, variables map[string]any) *TemplateSource {
+	return &TemplateSource{
+		Template:  tmpl,
+		Variables: variables,
+	}
+}
+
+// Read implements Source.
+func (s *TemplateSource) Read(_ context.Context) (string, error) {
+	var buf bytes.Buffer
+
+	if err := s.Template.Execute(&buf, s.Variables); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
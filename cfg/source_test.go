@@ -0,0 +1,28 @@
+package cfg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGroupConfigSectionsIntoChunksKeepsMultipleBannersWhole(t *testing.T) {
+	config := "banner motd\nMOTD text\nEOF\n!\n" +
+		"interface Ethernet1\n   description uplink\n!\n" +
+		"banner login\nWelcome\nEOF\n!\n" +
+		"interface Ethernet2\n   shutdown\n"
+
+	chunks := groupConfigSectionsIntoChunks(config, 100)
+
+	joined := strings.Join(chunks, "\n!\n")
+
+	for _, want := range []string{
+		"banner motd\nMOTD text\nEOF",
+		"interface Ethernet1\n   description uplink",
+		"banner login\nWelcome\nEOF",
+		"interface Ethernet2\n   shutdown",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("chunks %v missing whole section %q", chunks, want)
+		}
+	}
+}